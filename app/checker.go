@@ -0,0 +1,232 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Checker - kutilgan (expected) va haqiqiy (actual) natijani solishtirish usulini
+// belgilaydi. Har bir CheckerMode uchun bitta implementatsiya mavjud, shunda
+// executeSingleTestCase solishtirish mantig'ini o'zida saqlamaydi.
+type Checker interface {
+	Check(ctx context.Context, input, expected, actual string) (bool, error)
+}
+
+// getChecker - TestCase.CheckerMode bo'yicha mos Checker implementatsiyasini tanlaydi.
+// Bo'sh yoki noma'lum rejim "trimmed"ga tushadi, bu avvalgi standart xatti-harakat edi.
+func getChecker(tc TestCase, timeoutMs, memoryMb, cpuShares int) Checker {
+	switch tc.CheckerMode {
+	case "exact":
+		return exactChecker{}
+	case "tokenized":
+		return tokenizedChecker{}
+	case "float":
+		return floatChecker{epsilon: tc.Epsilon}
+	case "unordered_lines":
+		return unorderedLinesChecker{}
+	case "custom":
+		return customChecker{
+			cmd:       tc.CheckerCmd,
+			timeoutMs: timeoutMs,
+			memoryMb:  memoryMb,
+			cpuShares: cpuShares,
+		}
+	default:
+		return trimmedChecker{}
+	}
+}
+
+// exactChecker - hech qanday normallashtirishsiz bayt-ba-bayt solishtiradi
+type exactChecker struct{}
+
+func (exactChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	return actual == expected, nil
+}
+
+// trimmedChecker - har ikki tomondagi bosh/oxiridagi bo'sh joylarni olib tashlab solishtiradi
+type trimmedChecker struct{}
+
+func (trimmedChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	return strings.TrimSpace(actual) == strings.TrimSpace(expected), nil
+}
+
+// tokenizedChecker - ikkala matnni bo'sh joy bo'yicha token'larga ajratib,
+// token-ma-token solishtiradi, shuning uchun qatordagi bo'sh joylar soni
+// yoki satr uzilishlari farqi e'tiborga olinmaydi
+type tokenizedChecker struct{}
+
+func (tokenizedChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	expTokens := strings.Fields(expected)
+	actTokens := strings.Fields(actual)
+	if len(expTokens) != len(actTokens) {
+		return false, nil
+	}
+	for i := range expTokens {
+		if expTokens[i] != actTokens[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// floatChecker - token'larni son sifatida o'qib, ruxsat etilgan xatolik (epsilon)
+// doirasida solishtiradi. Epsilon berilmagan (<= 0) bo'lsa, kichik standart qiymat
+// ishlatiladi. Haqiqiy natijada son bo'lmagan token uchrasa, Wrong Answer sifatida
+// baholanadi; kutilgan natijadagi son bo'lmagan token esa xato sifatida qaytariladi.
+type floatChecker struct {
+	epsilon float64
+}
+
+func (c floatChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	expTokens := strings.Fields(expected)
+	actTokens := strings.Fields(actual)
+	if len(expTokens) != len(actTokens) {
+		return false, nil
+	}
+
+	epsilon := c.epsilon
+	if epsilon <= 0 {
+		epsilon = 1e-6
+	}
+
+	for i := range expTokens {
+		want, err := strconv.ParseFloat(expTokens[i], 64)
+		if err != nil {
+			return false, fmt.Errorf("kutilgan natijadagi %q qiymati son emas", expTokens[i])
+		}
+		got, err := strconv.ParseFloat(actTokens[i], 64)
+		if err != nil {
+			return false, nil
+		}
+		diff := math.Abs(want - got)
+		if diff > epsilon && diff > epsilon*math.Abs(want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// unorderedLinesChecker - satrlarni tartiblab solishtiradi, shunda chiqish tartibi
+// farq qilsa-da bir xil qatorlar to'plami to'g'ri deb hisoblanadi
+type unorderedLinesChecker struct{}
+
+func (unorderedLinesChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	expLines := strings.Split(strings.TrimSpace(expected), "\n")
+	actLines := strings.Split(strings.TrimSpace(actual), "\n")
+	if len(expLines) != len(actLines) {
+		return false, nil
+	}
+	for i := range expLines {
+		expLines[i] = strings.TrimSpace(expLines[i])
+		actLines[i] = strings.TrimSpace(actLines[i])
+	}
+	sort.Strings(expLines)
+	sort.Strings(actLines)
+	for i := range expLines {
+		if expLines[i] != actLines[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkerImage - custom checker'lar ishga tushiriladigan yagona, serverda qat'iy
+// belgilangan image. Mijoz so'rovidan image tanlashga ruxsat berilmaydi, aks holda
+// istalgan mijoz serverni ixtiyoriy ommaviy image'ni pull qilib ishga tushirishga
+// majburlashi mumkin edi.
+const checkerImage = "alpine:latest"
+
+// customChecker - foydalanuvchi bergan checker buyrug'ini alohida, tarmoqsiz
+// konteynerda ishga tushiradi. input/expected/actual /app ostida uchta faylga
+// yoziladi va checker `checker input.txt expected.txt actual.txt` ko'rinishida
+// chaqiriladi; exit code 0 - AC, boshqa har qanday qiymat - Wrong Answer.
+type customChecker struct {
+	cmd       string
+	timeoutMs int
+	memoryMb  int
+	cpuShares int
+}
+
+func (c customChecker) Check(ctx context.Context, input, expected, actual string) (bool, error) {
+	if c.cmd == "" {
+		return false, fmt.Errorf("custom checker uchun checker_cmd berilmagan")
+	}
+
+	workDir, err := ioutil.TempDir(os.TempDir(), "checker-*")
+	if err != nil {
+		return false, fmt.Errorf("checker katalogini yaratishda xato: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	files := map[string]string{"input.txt": input, "expected.txt": expected, "actual.txt": actual}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(workDir, name), []byte(content), 0644); err != nil {
+			return false, fmt.Errorf("%s faylini yozishda xato: %v", name, err)
+		}
+	}
+
+	cli, err := dockerEngineClient()
+	if err != nil {
+		return false, fmt.Errorf("docker mijozini olishda xato: %v", err)
+	}
+
+	timeoutMs := c.timeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = 5000
+	}
+	execCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	pidsLimit := int64(50)
+	resp, err := cli.ContainerCreate(execCtx, &container.Config{
+		Image:      checkerImage,
+		Cmd:        shCommand(fmt.Sprintf("%s input.txt expected.txt actual.txt", c.cmd)),
+		WorkingDir: "/app",
+	}, &container.HostConfig{
+		NetworkMode: "none",
+		Binds:       []string{workDir + ":/app"},
+		SecurityOpt: []string{"no-new-privileges"},
+		CapDrop:     []string{"ALL"},
+		Resources: container.Resources{
+			Memory:     int64(c.memoryMb) * 1024 * 1024,
+			MemorySwap: int64(c.memoryMb) * 1024 * 1024,
+			CPUShares:  int64(c.cpuShares),
+			PidsLimit:  &pidsLimit,
+		},
+	}, nil, nil, "")
+	if err != nil {
+		return false, fmt.Errorf("checker konteynerini yaratishda xato: %v", err)
+	}
+	defer cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+
+	if err := cli.ContainerStart(execCtx, resp.ID, container.StartOptions{}); err != nil {
+		return false, fmt.Errorf("checker konteynerini ishga tushirishda xato: %v", err)
+	}
+
+	statusCh, errCh := cli.ContainerWait(execCtx, resp.ID, container.WaitConditionNotRunning)
+	select {
+	case waitErr := <-errCh:
+		if execCtx.Err() == context.DeadlineExceeded {
+			return false, fmt.Errorf("checker vaqti tugadi")
+		}
+		return false, fmt.Errorf("checker konteynerini kutishda xato: %v", waitErr)
+	case <-statusCh:
+	}
+
+	inspect, err := cli.ContainerInspect(context.Background(), resp.ID)
+	if err != nil {
+		return false, fmt.Errorf("checker natijasini tekshirishda xato: %v", err)
+	}
+
+	return inspect.State.ExitCode == 0, nil
+}
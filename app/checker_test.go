@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExactChecker(t *testing.T) {
+	c := exactChecker{}
+	cases := []struct {
+		expected, actual string
+		want             bool
+	}{
+		{"hello\n", "hello\n", true},
+		{"hello\n", "hello", false}, // exact - trailing newline farqi ham muhim
+		{"hello", "hello", true},
+	}
+	for _, tc := range cases {
+		got, err := c.Check(context.Background(), "", tc.expected, tc.actual)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("exactChecker.Check(%q, %q) = %v, want %v", tc.expected, tc.actual, got, tc.want)
+		}
+	}
+}
+
+func TestTrimmedChecker(t *testing.T) {
+	c := trimmedChecker{}
+	got, err := c.Check(context.Background(), "", "  hello \n", "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("trimmedChecker should ignore leading/trailing whitespace")
+	}
+
+	got, err = c.Check(context.Background(), "", "hello world", "hello  world")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("trimmedChecker should not collapse internal whitespace differences")
+	}
+}
+
+func TestTokenizedChecker(t *testing.T) {
+	c := tokenizedChecker{}
+	got, err := c.Check(context.Background(), "", "1  2   3\n", "1 2 3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("tokenizedChecker should ignore whitespace amount/newlines between tokens")
+	}
+
+	got, err = c.Check(context.Background(), "", "1 2 3", "1 2 4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("tokenizedChecker should reject differing tokens")
+	}
+
+	got, err = c.Check(context.Background(), "", "1 2 3", "1 2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("tokenizedChecker should reject differing token counts")
+	}
+}
+
+func TestFloatChecker(t *testing.T) {
+	c := floatChecker{epsilon: 1e-3}
+
+	got, err := c.Check(context.Background(), "", "1.0001", "1.0002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("floatChecker should accept differences within epsilon")
+	}
+
+	got, err = c.Check(context.Background(), "", "1.0", "2.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("floatChecker should reject differences beyond epsilon")
+	}
+
+	if _, err := c.Check(context.Background(), "", "not-a-number", "1.0"); err == nil {
+		t.Errorf("floatChecker should error when the expected output is not numeric")
+	}
+
+	got, err = c.Check(context.Background(), "", "1.0", "not-a-number")
+	if err != nil {
+		t.Fatalf("non-numeric actual output should be Wrong Answer, not an error: %v", err)
+	}
+	if got {
+		t.Errorf("floatChecker should reject non-numeric actual output")
+	}
+}
+
+func TestFloatCheckerDefaultEpsilon(t *testing.T) {
+	c := floatChecker{} // epsilon berilmagan - standart qiymat ishlatilishi kerak
+	got, err := c.Check(context.Background(), "", "1.0", "1.0000001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("floatChecker with default epsilon should accept a tiny difference")
+	}
+}
+
+func TestUnorderedLinesChecker(t *testing.T) {
+	c := unorderedLinesChecker{}
+
+	got, err := c.Check(context.Background(), "", "a\nb\nc", "c\na\nb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got {
+		t.Errorf("unorderedLinesChecker should accept the same lines in a different order")
+	}
+
+	got, err = c.Check(context.Background(), "", "a\nb\nc", "a\nb\nd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("unorderedLinesChecker should reject a different set of lines")
+	}
+
+	got, err = c.Check(context.Background(), "", "a\nb", "a\nb\nb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got {
+		t.Errorf("unorderedLinesChecker should reject a different line count")
+	}
+}
+
+func TestGetChecker(t *testing.T) {
+	cases := []struct {
+		mode string
+		want Checker
+	}{
+		{"", trimmedChecker{}},
+		{"exact", exactChecker{}},
+		{"trimmed", trimmedChecker{}},
+		{"tokenized", tokenizedChecker{}},
+		{"unordered_lines", unorderedLinesChecker{}},
+		{"unknown-mode", trimmedChecker{}},
+	}
+	for _, tc := range cases {
+		got := getChecker(TestCase{CheckerMode: tc.mode}, 1000, 128, 512)
+		if got != tc.want {
+			t.Errorf("getChecker(mode=%q) = %#v, want %#v", tc.mode, got, tc.want)
+		}
+	}
+
+	if _, ok := getChecker(TestCase{CheckerMode: "float", Epsilon: 0.5}, 1000, 128, 512).(floatChecker); !ok {
+		t.Errorf("getChecker(mode=float) should return a floatChecker")
+	}
+	if _, ok := getChecker(TestCase{CheckerMode: "custom", CheckerCmd: "./checker"}, 1000, 128, 512).(customChecker); !ok {
+		t.Errorf("getChecker(mode=custom) should return a customChecker")
+	}
+}
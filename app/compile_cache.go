@@ -0,0 +1,132 @@
+package app
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// compileCacheMaxBytes - kompilyatsiya keshi egallashi mumkin bo'lgan maksimal hajm.
+// Undan oshib ketsa, eng uzoq vaqt ishlatilmagan (LRU) yozuvlar avtomatik o'chiriladi,
+// aks holda har bir yangi kod parchasi (masalan, bo'sh joy o'zgartirilgan nusxasi) diskda
+// abadiy qoladigan kesh yozuviga aylanadi. COMPILE_CACHE_MAX_MB orqali sozlanadi.
+var compileCacheMaxBytes = int64(envIntOrDefault("COMPILE_CACHE_MAX_MB", 2048)) * 1024 * 1024
+
+// compileCacheGracePeriod - yaqinda (shu muddat ichida) ishlatilgan kesh yozuvlari
+// hajm chegarasidan oshib ketgan taqdirda ham o'chirishdan himoyalanadi, chunki ular
+// hali ijro etilayotgan test case'lar tomonidan o'qilayotgan bo'lishi mumkin.
+const compileCacheGracePeriod = 60 * time.Second
+
+// touchCacheEntry - kesh yozuvining oxirgi ishlatilgan vaqtini yangilaydi, shunda
+// LRU tozalashda yaqinda ishlatilgan artefaktlar birinchi o'chiriladigan bo'lmaydi
+func touchCacheEntry(artifactDir string) {
+	now := time.Now()
+	os.Chtimes(artifactDir, now, now)
+}
+
+// artifactReaders - har bir kesh kaliti uchun hozir shu artefaktni o'qiyotgan
+// (ya'ni test case ijro etayotgan) so'rovlar sonini hisoblaydi. compileCacheGracePeriod
+// faqat kompilyatsiyadan keyingi qisqa muddatni himoya qiladi - ko'p test case'li
+// uzoq submission shu muddatdan oshib ketishi mumkin, shuning uchun eviction
+// artefakt hali o'qilayotganini bu hisobgich orqali ham tekshiradi.
+var artifactReaders sync.Map // map[string]*int32
+
+// acquireArtifactRef - cacheKey ijro etilayotgan paytda chaqiriladi; evictCompileCacheIfNeeded
+// shu kalit uchun hisobgich noldan katta ekanligini ko'rib, artefaktni o'tkazib yuboradi.
+func acquireArtifactRef(cacheKey string) {
+	counter, _ := artifactReaders.LoadOrStore(cacheKey, new(int32))
+	atomic.AddInt32(counter.(*int32), 1)
+}
+
+// releaseArtifactRef - acquireArtifactRef'ga mos ravishda, ijro tugagach chaqiriladi.
+func releaseArtifactRef(cacheKey string) {
+	if counter, ok := artifactReaders.Load(cacheKey); ok {
+		atomic.AddInt32(counter.(*int32), -1)
+	}
+}
+
+// artifactRefCount - cacheKey uchun hozir nechta so'rov ijro etilayotganini qaytaradi.
+func artifactRefCount(cacheKey string) int32 {
+	if counter, ok := artifactReaders.Load(cacheKey); ok {
+		return atomic.LoadInt32(counter.(*int32))
+	}
+	return 0
+}
+
+// evictCompileCacheIfNeeded - compileCacheRoot hajmi compileCacheMaxBytes'dan oshsa,
+// eng eski (mtime bo'yicha eng uzoq vaqt ishlatilmagan) yozuvlarni, hajm chegara
+// ostiga tushguncha o'chiradi. Yaqinda ishlatilgan yozuvlar va hozir band bo'lgan
+// (compileLockFor qulfi band) yozuvlar tegilmaydi.
+func evictCompileCacheIfNeeded() {
+	entries, err := ioutil.ReadDir(compileCacheRoot)
+	if err != nil {
+		return // Kesh katalogi hali mavjud emas yoki o'qib bo'lmadi - tozalash shart emas
+	}
+
+	type cacheEntry struct {
+		path    string
+		key     string
+		size    int64
+		modTime time.Time
+	}
+
+	var cacheEntries []cacheEntry
+	var total int64
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dirPath := filepath.Join(compileCacheRoot, e.Name())
+		size := dirSize(dirPath)
+		total += size
+		cacheEntries = append(cacheEntries, cacheEntry{path: dirPath, key: e.Name(), size: size, modTime: e.ModTime()})
+	}
+	if total <= compileCacheMaxBytes {
+		return
+	}
+
+	sort.Slice(cacheEntries, func(i, j int) bool { return cacheEntries[i].modTime.Before(cacheEntries[j].modTime) })
+
+	cutoff := time.Now().Add(-compileCacheGracePeriod)
+	for _, ce := range cacheEntries {
+		if total <= compileCacheMaxBytes {
+			break
+		}
+		if ce.modTime.After(cutoff) {
+			continue // Yaqinda ishlatilgan, hozircha tegmaymiz
+		}
+		if artifactRefCount(ce.key) > 0 {
+			continue // Hali kamida bitta ijro shu artefaktni o'qimoqda
+		}
+
+		lock := compileLockFor(ce.key)
+		if !lock.TryLock() {
+			continue // Hozir boshqa so'rov tomonidan ishlatilyapti
+		}
+		if err := os.RemoveAll(ce.path); err != nil {
+			log.Printf("Kesh yozuvini o'chirishda xato (%s): %v", ce.path, err)
+			lock.Unlock()
+			continue
+		}
+		total -= ce.size
+		compileLocks.Delete(ce.key)
+		lock.Unlock()
+	}
+}
+
+// dirSize - katalogdagi barcha fayllarning jami hajmini baytlarda hisoblaydi
+func dirSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"sync"
+
+	"github.com/docker/docker/client"
+)
+
+var (
+	dockerClientOnce sync.Once
+	dockerClientInst *client.Client
+	dockerClientErr  error
+)
+
+// dockerEngineClient - Docker Engine API uchun bitta marta ishga tushiriladigan
+// umumiy mijoz. `docker` CLI binarini chaqirish o'rniga barcha konteyner amallari
+// (create/start/exec/inspect/remove) shu mijoz orqali bajariladi.
+func dockerEngineClient() (*client.Client, error) {
+	dockerClientOnce.Do(func() {
+		dockerClientInst, dockerClientErr = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	})
+	return dockerClientInst, dockerClientErr
+}
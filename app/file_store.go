@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileTestCaseStore - har bir muammo uchun <rootDir>/<problem_id>.json faylida
+// test case'larni saqlaydigan TestCaseStore implementatsiyasi. Fixture'larni
+// kod bilan birga versiyalash yoki Postgres'siz lokal ishlatish uchun qulay.
+type FileTestCaseStore struct {
+	mu      sync.RWMutex
+	rootDir string
+}
+
+// NewFileTestCaseStore - berilgan katalogni fixture ildizi sifatida ishlatadigan
+// store yaratadi. Katalog mavjud bo'lmasa, SaveTestCases chaqirilganda yaratiladi.
+func NewFileTestCaseStore(rootDir string) *FileTestCaseStore {
+	return &FileTestCaseStore{rootDir: rootDir}
+}
+
+func (s *FileTestCaseStore) problemPath(problemID int) string {
+	return filepath.Join(s.rootDir, fmt.Sprintf("%d.json", problemID))
+}
+
+func (s *FileTestCaseStore) FetchByProblemID(ctx context.Context, problemID int) ([]TestCase, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := ioutil.ReadFile(s.problemPath(problemID))
+	if err != nil {
+		return nil, fmt.Errorf("problem ID %d uchun fixture topilmadi: %v", problemID, err)
+	}
+	var testcases []TestCase
+	if err := json.Unmarshal(data, &testcases); err != nil {
+		return nil, fmt.Errorf("fixture faylini o'qishda xato: %v", err)
+	}
+	if len(testcases) == 0 {
+		return nil, fmt.Errorf("problem ID %d uchun testcase'lar topilmadi", problemID)
+	}
+	return testcases, nil
+}
+
+// SaveTestCases - berilgan test case'larni problem ID bo'yicha JSON fayliga yozadi,
+// mavjud faylni to'liq almashtiradi
+func (s *FileTestCaseStore) SaveTestCases(ctx context.Context, problemID int, cases []TestCase) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.rootDir, 0755); err != nil {
+		return fmt.Errorf("fixture katalogini yaratishda xato: %v", err)
+	}
+	data, err := json.MarshalIndent(cases, "", "  ")
+	if err != nil {
+		return fmt.Errorf("fixture'larni marshal qilishda xato: %v", err)
+	}
+	if err := ioutil.WriteFile(s.problemPath(problemID), data, 0644); err != nil {
+		return fmt.Errorf("fixture faylini yozishda xato: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LanguageRuntime - bitta dasturlash tili uchun kompilyatsiya/ijro konfiguratsiyasi.
+// CompileCmd bo'sh (nil) bo'lsa, til interpretatsiya qilinadi deb hisoblanadi va
+// alohida kompilyatsiya bosqichisiz to'g'ridan-to'g'ri RunCmd ishga tushiriladi.
+type LanguageRuntime struct {
+	FileName   string                                    // Manba kod fayli nomi (masalan, main.cpp)
+	Image      string                                    // Bazaviy Docker image
+	CompileCmd func(fileName string) []string            // Kompilyatsiya buyrug'i (compiled tillar uchun)
+	RunCmd     func(fileName, inputPath string) []string // Ijro buyrug'i
+}
+
+var (
+	runtimesMu sync.RWMutex
+	runtimes   = map[string]LanguageRuntime{}
+)
+
+// RegisterLanguage - yangi til runtime'ini reestrga qo'shadi yoki mavjudini almashtiradi.
+// Bu orqali yangi tillar (Rust, Kotlin, TypeScript, C# va hokazo) switch-case'larni
+// tahrirlamasdan, runtime vaqtida qo'shilishi mumkin.
+func RegisterLanguage(name string, rt LanguageRuntime) {
+	runtimesMu.Lock()
+	defer runtimesMu.Unlock()
+	runtimes[name] = rt
+}
+
+// getLanguageRuntime - ro'yxatdan o'tgan til runtime'ini qaytaradi.
+// Ikkinchi qiymat til topilmagan bo'lsa false bo'ladi.
+func getLanguageRuntime(name string) (LanguageRuntime, bool) {
+	runtimesMu.RLock()
+	defer runtimesMu.RUnlock()
+	rt, ok := runtimes[name]
+	return rt, ok
+}
+
+// isCompiled - runtime uchun alohida kompilyatsiya bosqichi kerakligini bildiradi
+func (rt LanguageRuntime) isCompiled() bool {
+	return rt.CompileCmd != nil
+}
+
+// shCommand - "sh -c <script>" ko'rinishidagi Docker buyrug'ini quradi
+func shCommand(script string) []string {
+	return []string{"sh", "-c", script}
+}
+
+// redirectInput - inputPath bo'sh bo'lmasa, stdin yo'naltirishni qaytaradi
+func redirectInput(inputPath string) string {
+	if inputPath == "" {
+		return ""
+	}
+	return fmt.Sprintf("< %s", inputPath)
+}
+
+func init() {
+	RegisterLanguage("python", LanguageRuntime{
+		FileName: "main.py",
+		Image:    "python:3.12.10-alpine",
+		RunCmd: func(fileName, inputPath string) []string {
+			return shCommand(fmt.Sprintf("python /app/%s %s", fileName, redirectInput(inputPath)))
+		},
+	})
+
+	RegisterLanguage("javascript", LanguageRuntime{
+		FileName: "index.js",
+		Image:    "node:22.16.0-alpine",
+		RunCmd: func(fileName, inputPath string) []string {
+			return shCommand(fmt.Sprintf("node /app/%s %s", fileName, redirectInput(inputPath)))
+		},
+	})
+
+	RegisterLanguage("java", LanguageRuntime{
+		FileName: "Main.java",
+		Image:    "openjdk:17-jdk-slim",
+		CompileCmd: func(fileName string) []string {
+			return shCommand(fmt.Sprintf("javac /app/%s -d /app", fileName))
+		},
+		RunCmd: func(fileName, inputPath string) []string {
+			return shCommand(fmt.Sprintf("java -classpath /app Main %s", redirectInput(inputPath)))
+		},
+	})
+
+	RegisterLanguage("cpp", LanguageRuntime{
+		FileName: "main.cpp",
+		Image:    "gcc:latest",
+		CompileCmd: func(fileName string) []string {
+			return shCommand(fmt.Sprintf("g++ -O2 -o /app/a.out /app/%s", fileName))
+		},
+		RunCmd: func(fileName, inputPath string) []string {
+			return shCommand(fmt.Sprintf("/app/a.out %s", redirectInput(inputPath)))
+		},
+	})
+
+	RegisterLanguage("go", LanguageRuntime{
+		FileName: "main.go",
+		Image:    "golang:1.22-alpine",
+		CompileCmd: func(fileName string) []string {
+			return shCommand(fmt.Sprintf("go build -o /app/a.out /app/%s", fileName))
+		},
+		RunCmd: func(fileName, inputPath string) []string {
+			return shCommand(fmt.Sprintf("/app/a.out %s", redirectInput(inputPath)))
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MemoryTestCaseStore - hech qanday tashqi bog'liqliksiz, faqat jarayon xotirasida
+// saqlaydigan TestCaseStore implementatsiyasi. Testlar va qisqa muddatli
+// integratsiyalar uchun mo'ljallangan.
+type MemoryTestCaseStore struct {
+	mu   sync.RWMutex
+	data map[int][]TestCase
+}
+
+// NewMemoryTestCaseStore - bo'sh xotiradagi store yaratadi
+func NewMemoryTestCaseStore() *MemoryTestCaseStore {
+	return &MemoryTestCaseStore{data: make(map[int][]TestCase)}
+}
+
+func (s *MemoryTestCaseStore) FetchByProblemID(ctx context.Context, problemID int) ([]TestCase, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	testcases, ok := s.data[problemID]
+	if !ok || len(testcases) == 0 {
+		return nil, fmt.Errorf("problem ID %d uchun testcase'lar topilmadi", problemID)
+	}
+	return testcases, nil
+}
+
+// SaveTestCases - berilgan test case'larni xotirada problem ID bo'yicha saqlaydi,
+// oldingi qiymatni to'liq almashtiradi
+func (s *MemoryTestCaseStore) SaveTestCases(ctx context.Context, problemID int, cases []TestCase) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[problemID] = cases
+	return nil
+}
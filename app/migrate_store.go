@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MigrateFromDirectory - "<rootDir>/<problem_id>/{input,output}_N.txt" ko'rinishidagi
+// katalog tuzilishini o'qib, berilgan TestCaseWriter orqali istalgan backing store'ga
+// (Postgres, SQLite, fayl yoki xotiradagi) yozadi. rootDir ostidagi raqamli nomga ega
+// bo'lmagan kataloglar e'tiborga olinmaydi.
+func MigrateFromDirectory(ctx context.Context, rootDir string, dest TestCaseWriter) error {
+	entries, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return fmt.Errorf("%s katalogini o'qishda xato: %v", rootDir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		problemID, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		problemDir := filepath.Join(rootDir, entry.Name())
+		cases, err := loadTestCasesFromProblemDir(problemDir)
+		if err != nil {
+			return fmt.Errorf("muammo %d uchun test case'larni o'qishda xato: %v", problemID, err)
+		}
+		if err := dest.SaveTestCases(ctx, problemID, cases); err != nil {
+			return fmt.Errorf("muammo %d uchun test case'larni yozishda xato: %v", problemID, err)
+		}
+	}
+	return nil
+}
+
+// loadTestCasesFromProblemDir - bitta muammo katalogidagi input_N.txt/output_N.txt
+// juftliklarini N tartibida o'qib, TestCase ro'yxatiga yig'adi
+func loadTestCasesFromProblemDir(dir string) ([]TestCase, error) {
+	inputFiles, err := filepath.Glob(filepath.Join(dir, "input_*.txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	// input_N.txt'lardagi N raqamini oldindan ajratib olamiz, chunki sort.Strings
+	// lug'aviy tartibda ishlaydi (input_10.txt input_2.txt'dan oldin keladi) va bu
+	// 10 tadan ko'p test case'li muammolarda pozitsiyalarni buzib yuboradi.
+	type indexedFile struct {
+		path string
+		n    int
+	}
+	files := make([]indexedFile, 0, len(inputFiles))
+	for _, inputPath := range inputFiles {
+		base := filepath.Base(inputPath)
+		n := strings.TrimSuffix(strings.TrimPrefix(base, "input_"), ".txt")
+		num, _ := strconv.Atoi(n)
+		files = append(files, indexedFile{path: inputPath, n: num})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].n < files[j].n })
+
+	var cases []TestCase
+	for _, f := range files {
+		outputPath := filepath.Join(dir, fmt.Sprintf("output_%d.txt", f.n))
+
+		input, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("%s faylini o'qishda xato: %v", f.path, err)
+		}
+		output, err := ioutil.ReadFile(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s faylini o'qishda xato: %v", outputPath, err)
+		}
+
+		cases = append(cases, TestCase{ID: f.n, InputText: string(input), OutputText: string(output)})
+	}
+	return cases, nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTestCaseStore - umumiy pgxpool.Pool orqali test case'larni Postgres'dan
+// o'qiydigan TestCaseStore implementatsiyasi. Ulanish DSN'i faqat chaqiruvchi
+// tomonidan (odatda DATABASE_URL muhit o'zgaruvchisidan) beriladi, manbada
+// hech qanday hardcoded ulanish ma'lumoti saqlanmaydi.
+type PostgresTestCaseStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTestCaseStore - berilgan DSN asosida umumiy ulanish pulini yaratadi.
+// Pool butun ilova hayoti davomida qayta ishlatilishi kerak, har bir so'rov uchun
+// yangi ulanish ochilmaydi.
+func NewPostgresTestCaseStore(ctx context.Context, dsn string) (*PostgresTestCaseStore, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("DATABASE_URL muhit o'zgaruvchisi topilmadi")
+	}
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("ma'lumotlar bazasi puliga ulanishda xato: %v", err)
+	}
+	return &PostgresTestCaseStore{pool: pool}, nil
+}
+
+// Close - pool'ni va uning barcha ulanishlarini yopadi
+func (s *PostgresTestCaseStore) Close() {
+	s.pool.Close()
+}
+
+func (s *PostgresTestCaseStore) FetchByProblemID(ctx context.Context, problemID int) ([]TestCase, error) {
+	query := "SELECT id, input_txt, output_txt FROM problems_testcase WHERE problem_id=$1 ORDER BY id"
+	rows, err := s.pool.Query(ctx, query, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("so'rovni bajarishda xato: %v", err)
+	}
+	defer rows.Close()
+
+	var testcases []TestCase
+	for rows.Next() {
+		var tc TestCase
+		if err := rows.Scan(&tc.ID, &tc.InputText, &tc.OutputText); err != nil {
+			return nil, fmt.Errorf("ma'lumotni o'qishda xato: %v", err)
+		}
+		testcases = append(testcases, tc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteratsiyasida xato: %v", err)
+	}
+	if len(testcases) == 0 {
+		return nil, fmt.Errorf("problem ID %d uchun testcase'lar topilmadi", problemID)
+	}
+	return testcases, nil
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resourceUsage - bitta docker exec ijrosi davomida kuzatilgan eng yuqori
+// xotira va jami CPU vaqti
+type resourceUsage struct {
+	peakMemoryKb float64
+	cpuTimeMs    int64
+	oomKilled    bool
+}
+
+// readCgroupPeakMemoryKb - cgroups v2 muhitida konteynerning memory.peak faylini
+// o'qishga harakat qiladi. cgroup driver'iga qarab yo'l farq qilishi mumkin,
+// shuning uchun bir nechta odatiy joylashuv sinab ko'riladi. Fayl topilmasa
+// (masalan cgroups v1 muhitida), ikkinchi qiymat false bo'ladi va chaqiruvchi
+// Docker stats API orqali zaxira o'lchov qilishi kerak.
+func readCgroupPeakMemoryKb(containerID string) (float64, bool) {
+	candidates := []string{
+		fmt.Sprintf("/sys/fs/cgroup/system.slice/docker-%s.scope/memory.peak", containerID),
+		fmt.Sprintf("/sys/fs/cgroup/docker/%s/memory.peak", containerID),
+	}
+	for _, path := range candidates {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		val, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+		if err != nil {
+			continue
+		}
+		return val / 1024, true
+	}
+	return 0, false
+}
+
+// sampleContainerResources - sampleCtx bekor qilinguncha konteynerning xotira
+// va CPU sarfini qisqa intervallarda kuzatadi. Xotira uchun cgroups v2 memory.peak
+// mavjud bo'lsa undan, aks holda Docker stats API'dan, CPU vaqti uchun esa doimo
+// Docker stats API'dan foydalaniladi. Xotira sarfi memoryLimitMb ning 98% iga
+// yetsa OOM sifatida belgilanadi, hatto jarayon toza chiqsa ham.
+func sampleContainerResources(sampleCtx context.Context, containerID string, memoryLimitMb int) *resourceUsage {
+	usage := &resourceUsage{}
+	cli, cliErr := dockerEngineClient()
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+
+	memoryLimitKb := float64(memoryLimitMb) * 1024
+
+	sample := func() {
+		cgroupPeakKb, cgroupOk := readCgroupPeakMemoryKb(containerID)
+		if cgroupOk && cgroupPeakKb > usage.peakMemoryKb {
+			usage.peakMemoryKb = cgroupPeakKb
+		}
+		if cliErr == nil {
+			statsResp, err := cli.ContainerStatsOneShot(sampleCtx, containerID)
+			if err == nil {
+				var stats containerStats
+				if json.NewDecoder(statsResp.Body).Decode(&stats) == nil {
+					memKb := float64(stats.MemoryStats.Usage) / 1024
+					if !cgroupOk && memKb > usage.peakMemoryKb {
+						usage.peakMemoryKb = memKb
+					}
+					cpuMs := int64(stats.CPUStats.CPUUsage.TotalUsage / 1_000_000)
+					if cpuMs > usage.cpuTimeMs {
+						usage.cpuTimeMs = cpuMs
+					}
+				}
+				statsResp.Body.Close()
+			}
+		}
+
+		if memoryLimitKb > 0 && usage.peakMemoryKb >= memoryLimitKb*0.98 {
+			usage.oomKilled = true
+		}
+	}
+
+	for {
+		select {
+		case <-sampleCtx.Done():
+			sample() // Oxirgi holatni ham yozib qo'yamiz
+			return usage
+		case <-ticker.C:
+			sample()
+		}
+	}
+}
+
+// containerStats - Docker stats API javobidan bizga kerakli maydonlarning qisqartirilgan shakli
+type containerStats struct {
+	MemoryStats struct {
+		Usage uint64 `json:"usage"`
+	} `json:"memory_stats"`
+	CPUStats struct {
+		CPUUsage struct {
+			TotalUsage uint64 `json:"total_usage"`
+		} `json:"cpu_usage"`
+	} `json:"cpu_stats"`
+}
@@ -0,0 +1,358 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// pooledContainer - SandboxPool tomonidan boshqariladigan bitta "iliq" konteyner.
+// slotDir hostdagi katalog bo'lib, konteynerda /app ga bind-mount qilingan;
+// har bir ijrodan oldin joriy kodning artefakti shu katalogga nusxalanadi.
+type pooledContainer struct {
+	id        string
+	language  string
+	memoryMb  int
+	cpuShares int
+	slotDir   string
+	execCount int
+}
+
+// SandboxPool - til bo'yicha oldindan yaratilgan, ishga tushirilgan konteynerlarni
+// "iliq" holatda ushlab turadi va har bir test case'ni `docker run` o'rniga
+// `docker exec` orqali bajaradi, shu bilan Docker ishga tushirish xarajatini yo'qotadi.
+type SandboxPool struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	idle map[string][]*pooledContainer // kalit: poolKey(language, memoryMb, cpuShares)
+	live map[string]int                // kalit bo'yicha hozir mavjud (band yoki idle) konteynerlar soni
+
+	size     int // har bir til/limit kombinatsiyasi uchun bir vaqtda mavjud bo'lishi mumkin bo'lgan konteynerlar soni
+	maxReuse int // konteyner yo'q qilinmasdan oldin qayta ishlatilishi mumkin bo'lgan maksimal exec soni
+}
+
+// defaultSandboxPool - ExecuteCode tomonidan ishlatiladigan umumiy pool
+var defaultSandboxPool = NewSandboxPool(envIntOrDefault("SANDBOX_POOL_SIZE", 2), envIntOrDefault("SANDBOX_MAX_REUSE", 50))
+
+// envIntOrDefault - muhit o'zgaruvchisidan butun son o'qiydi, topilmasa yoki noto'g'ri bo'lsa defaultni qaytaradi
+func envIntOrDefault(key string, def int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// NewSandboxPool - berilgan sig'im (har bir til/limit kombinatsiyasi uchun iliq
+// konteynerlar soni) va qayta ishlatish chegarasi bilan yangi pool yaratadi
+func NewSandboxPool(size, maxReuse int) *SandboxPool {
+	if size < 1 {
+		size = 1
+	}
+	if maxReuse < 1 {
+		maxReuse = 1
+	}
+	p := &SandboxPool{
+		idle:     make(map[string][]*pooledContainer),
+		live:     make(map[string]int),
+		size:     size,
+		maxReuse: maxReuse,
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+func poolKey(language string, memoryMb, cpuShares int) string {
+	return fmt.Sprintf("%s-%d-%d", language, memoryMb, cpuShares)
+}
+
+// acquire - pool'dan bo'sh turgan konteynerni oladi; agar idle ro'yxati bo'sh bo'lsa-yu
+// shu kalit uchun mavjud konteynerlar soni hali p.size'dan kam bo'lsa yangisini yaratadi,
+// aks holda boshqa bir konteyner release (yoki yo'q qilinib) bo'shaguncha navbatda
+// kutadi. Shu tufayli bitta so'rovning SANDBOX_POOL_SIZE'dan ko'p test case'lari ham
+// iliq pool orqali navbat bilan o'tadi - har biri alohida konteyner yaratib, chunk0-2
+// keltirgan tejamni yo'qqa chiqarmaydi.
+func (p *SandboxPool) acquire(ctx context.Context, rt LanguageRuntime, language string, memoryMb, cpuShares int) (*pooledContainer, error) {
+	key := poolKey(language, memoryMb, cpuShares)
+
+	// ctx bekor qilinsa, navbatda kutayotgan goroutine'ni uyg'otish uchun
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.cond.Broadcast()
+		case <-done:
+		}
+	}()
+
+	p.mu.Lock()
+	for {
+		if list := p.idle[key]; len(list) > 0 {
+			c := list[len(list)-1]
+			p.idle[key] = list[:len(list)-1]
+			p.mu.Unlock()
+			return c, nil
+		}
+
+		if p.live[key] < p.size {
+			p.live[key]++
+			p.mu.Unlock()
+			c, err := p.createContainer(ctx, rt, language, memoryMb, cpuShares)
+			if err != nil {
+				p.mu.Lock()
+				p.live[key]--
+				p.mu.Unlock()
+				p.cond.Broadcast() // joy bo'shadi - navbatdagi boshqa kutuvchi urinib ko'rsin
+				return nil, err
+			}
+			return c, nil
+		}
+
+		if ctx.Err() != nil {
+			p.mu.Unlock()
+			return nil, ctx.Err()
+		}
+
+		p.cond.Wait() // release yoki destroyContainer orqali joy bo'shashini kutamiz
+	}
+}
+
+// release - konteynerni ishlatishni tugatgandan so'ng chaqiriladi. Agar konteyner
+// qayta ishlatish chegarasiga yetgan yoki nosog'lom (masalan OOM yoki timeout) bo'lsa,
+// u yo'q qilinadi; aks holda keyingi test case uchun pool'ga qaytariladi. Har ikki holatda
+// ham navbatda turgan acquire chaqiruvlari cond.Broadcast orqali uyg'otiladi.
+func (p *SandboxPool) release(c *pooledContainer, unhealthy bool) {
+	c.execCount++
+	key := poolKey(c.language, c.memoryMb, c.cpuShares)
+
+	if unhealthy || c.execCount >= p.maxReuse {
+		p.destroyContainer(c)
+		p.mu.Lock()
+		p.live[key]--
+		p.mu.Unlock()
+		p.cond.Broadcast()
+		return
+	}
+
+	p.mu.Lock()
+	if len(p.idle[key]) >= p.size {
+		p.mu.Unlock()
+		p.destroyContainer(c)
+		p.mu.Lock()
+		p.live[key]--
+		p.mu.Unlock()
+		p.cond.Broadcast()
+		return
+	}
+	p.idle[key] = append(p.idle[key], c)
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+func (p *SandboxPool) createContainer(ctx context.Context, rt LanguageRuntime, language string, memoryMb, cpuShares int) (*pooledContainer, error) {
+	cli, err := dockerEngineClient()
+	if err != nil {
+		return nil, fmt.Errorf("docker mijozini olishda xato: %v", err)
+	}
+
+	slotDir, err := ioutil.TempDir(os.TempDir(), fmt.Sprintf("sandbox-slot-%s-*", language))
+	if err != nil {
+		return nil, fmt.Errorf("sandbox slot katalogini yaratishda xato: %v", err)
+	}
+
+	pidsLimit := int64(100)
+	resp, err := cli.ContainerCreate(ctx, &container.Config{
+		Image: rt.Image,
+		Cmd:   []string{"sleep", "infinity"},
+	}, &container.HostConfig{
+		NetworkMode: "none",
+		Binds:       []string{slotDir + ":/app"},
+		SecurityOpt: []string{"no-new-privileges"},
+		CapDrop:     []string{"ALL"},
+		Resources: container.Resources{
+			Memory:     int64(memoryMb) * 1024 * 1024,
+			MemorySwap: int64(memoryMb) * 1024 * 1024,
+			CPUShares:  int64(cpuShares),
+			PidsLimit:  &pidsLimit,
+		},
+	}, nil, nil, "")
+	if err != nil {
+		os.RemoveAll(slotDir)
+		return nil, fmt.Errorf("konteyner yaratishda xato: %v", err)
+	}
+
+	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+		os.RemoveAll(slotDir)
+		return nil, fmt.Errorf("konteynerni ishga tushirishda xato: %v", err)
+	}
+
+	return &pooledContainer{
+		id:        resp.ID,
+		language:  language,
+		memoryMb:  memoryMb,
+		cpuShares: cpuShares,
+		slotDir:   slotDir,
+	}, nil
+}
+
+func (p *SandboxPool) destroyContainer(c *pooledContainer) {
+	cli, err := dockerEngineClient()
+	if err != nil {
+		log.Printf("Konteynerni o'chirish uchun docker mijozini olishda xato (ID: %s): %v", c.id, err)
+	} else if err := cli.ContainerRemove(context.Background(), c.id, container.RemoveOptions{Force: true}); err != nil {
+		log.Printf("Konteynerni o'chirishda xato (ID: %s): %v", c.id, err)
+	}
+	os.RemoveAll(c.slotDir)
+}
+
+// execResult - konteyner ichida bajarilgan buyruqning natijasi. ExitCode va
+// xatolar endi Docker Engine API orqali struktura holida olinadi, stderr
+// matnini tekshirish orqali emas.
+type execResult struct {
+	stdout       string
+	stderr       string
+	exitCode     int
+	timedOut     bool
+	cancelled    bool
+	oomKilled    bool
+	peakMemoryKb float64
+	cpuTimeMs    int64
+	internalErr  error
+}
+
+// exec - runCommand'ni konteyner ichida docker exec orqali bajaradi, stdin orqali
+// input yuboradi va timeout tugashi bilan context orqali bekor qiladi. Ijro
+// davomida konteynerning xotira/CPU sarfi alohida goroutine'da kuzatiladi.
+func (c *pooledContainer) exec(ctx context.Context, runCommand []string, input string, timeoutMs int) execResult {
+	cli, err := dockerEngineClient()
+	if err != nil {
+		return execResult{internalErr: fmt.Errorf("docker mijozini olishda xato: %v", err)}
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	sampleCtx, stopSampling := context.WithCancel(context.Background())
+	usageCh := make(chan *resourceUsage, 1)
+	go func() {
+		usageCh <- sampleContainerResources(sampleCtx, c.id, c.memoryMb)
+	}()
+	finishSampling := func() *resourceUsage {
+		stopSampling()
+		return <-usageCh
+	}
+
+	created, err := cli.ContainerExecCreate(execCtx, c.id, container.ExecOptions{
+		Cmd:          runCommand,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		finishSampling()
+		return execResult{internalErr: fmt.Errorf("exec yaratishda xato: %v", err)}
+	}
+
+	attachResp, err := cli.ContainerExecAttach(execCtx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		finishSampling()
+		return execResult{internalErr: fmt.Errorf("exec'ga ulanishda xato: %v", err)}
+	}
+	defer attachResp.Close()
+
+	go func() {
+		io.Copy(attachResp.Conn, strings.NewReader(input))
+		attachResp.CloseWrite()
+	}()
+
+	var stdout, stderr bytes.Buffer
+	copyDone := make(chan error, 1)
+	go func() {
+		_, copyErr := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader)
+		copyDone <- copyErr
+	}()
+
+	select {
+	case <-execCtx.Done():
+		usage := finishSampling()
+		if ctx.Err() != nil {
+			// Tashqi context (masalan, foydalanuvchining "cancel" so'rovi) bekor qilingan,
+			// bu timeout emas
+			return execResult{stdout: stdout.String(), stderr: stderr.String(), cancelled: true, peakMemoryKb: usage.peakMemoryKb, cpuTimeMs: usage.cpuTimeMs}
+		}
+		return execResult{stdout: stdout.String(), stderr: stderr.String(), timedOut: true, peakMemoryKb: usage.peakMemoryKb, cpuTimeMs: usage.cpuTimeMs}
+	case <-copyDone:
+	}
+
+	usage := finishSampling()
+
+	inspect, err := cli.ContainerExecInspect(context.Background(), created.ID)
+	if err != nil {
+		return execResult{stdout: stdout.String(), stderr: stderr.String(), internalErr: fmt.Errorf("exec holatini tekshirishda xato: %v", err), peakMemoryKb: usage.peakMemoryKb, cpuTimeMs: usage.cpuTimeMs}
+	}
+
+	res := execResult{
+		stdout:       stdout.String(),
+		stderr:       stderr.String(),
+		exitCode:     inspect.ExitCode,
+		peakMemoryKb: usage.peakMemoryKb,
+		cpuTimeMs:    usage.cpuTimeMs,
+	}
+	// 137 = 128 + SIGKILL(9), cgroup OOM killer jarayonni shu signal bilan to'xtatadi.
+	// cgroup xotira sarfi limitga yetgan bo'lsa ham (usage.oomKilled), jarayon toza
+	// chiqqan taqdirda ham MLE sifatida belgilanadi.
+	if inspect.ExitCode == 137 || usage.oomKilled {
+		res.oomKilled = true
+	}
+	return res
+}
+
+// syncArtifact - artifactDir ichidagi fayllarni konteynerning slotDir'iga nusxalaydi,
+// shu bilan bind-mount qayta ishlatilgan bo'lsa-da joriy kodning artefakti konteynerda
+// ko'rinadigan bo'ladi
+func syncArtifact(artifactDir, slotDir string) error {
+	entries, err := ioutil.ReadDir(artifactDir)
+	if err != nil {
+		return fmt.Errorf("artefakt katalogini o'qishda xato: %v", err)
+	}
+
+	// Eski slot tarkibini tozalash, chunki oldingi test case boshqa kodga tegishli bo'lishi mumkin
+	oldEntries, err := ioutil.ReadDir(slotDir)
+	if err == nil {
+		for _, e := range oldEntries {
+			os.RemoveAll(filepath.Join(slotDir, e.Name()))
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == ".compiled" {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(artifactDir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("artefakt faylini o'qishda xato: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(slotDir, entry.Name()), data, 0755); err != nil {
+			return fmt.Errorf("artefakt faylini slotga yozishda xato: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteTestCaseStore - bitta mahalliy SQLite fayli orqali test case'larni
+// saqlaydigan TestCaseStore implementatsiyasi. Postgres talab qilmaydigan
+// engil joylashtirishlar (masalan, CI yoki lokal rivojlantirish) uchun mo'ljallangan.
+type SQLiteTestCaseStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteTestCaseStore - berilgan fayl yo'lida SQLite bazasini ochadi va
+// testcases jadvali mavjud bo'lmasa yaratadi
+func NewSQLiteTestCaseStore(path string) (*SQLiteTestCaseStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite bazasini ochishda xato: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS testcases (
+		problem_id INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		input_text TEXT NOT NULL,
+		output_text TEXT NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite jadvalini yaratishda xato: %v", err)
+	}
+	return &SQLiteTestCaseStore{db: db}, nil
+}
+
+// Close - ochiq SQLite ulanishini yopadi
+func (s *SQLiteTestCaseStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteTestCaseStore) FetchByProblemID(ctx context.Context, problemID int) ([]TestCase, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT position, input_text, output_text FROM testcases WHERE problem_id = ? ORDER BY position`, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("so'rovni bajarishda xato: %v", err)
+	}
+	defer rows.Close()
+
+	var testcases []TestCase
+	for rows.Next() {
+		var tc TestCase
+		if err := rows.Scan(&tc.ID, &tc.InputText, &tc.OutputText); err != nil {
+			return nil, fmt.Errorf("ma'lumotni o'qishda xato: %v", err)
+		}
+		testcases = append(testcases, tc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteratsiyasida xato: %v", err)
+	}
+	if len(testcases) == 0 {
+		return nil, fmt.Errorf("problem ID %d uchun testcase'lar topilmadi", problemID)
+	}
+	return testcases, nil
+}
+
+// SaveTestCases - mavjud test case'larni o'chirib, berilganlarini yozadi. Eski
+// yozuvlarni almashtirib qo'yishi MigrateFromDirectory'ni qayta-qayta ishga
+// tushirishni xavfsiz qiladi.
+func (s *SQLiteTestCaseStore) SaveTestCases(ctx context.Context, problemID int, cases []TestCase) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("tranzaksiyani boshlashda xato: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM testcases WHERE problem_id = ?`, problemID); err != nil {
+		return fmt.Errorf("eski test case'larni o'chirishda xato: %v", err)
+	}
+	for i, tc := range cases {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO testcases (problem_id, position, input_text, output_text) VALUES (?, ?, ?, ?)`, problemID, i, tc.InputText, tc.OutputText); err != nil {
+			return fmt.Errorf("test case'ni yozishda xato: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tranzaksiyani tasdiqlashda xato: %v", err)
+	}
+	return nil
+}
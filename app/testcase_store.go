@@ -0,0 +1,20 @@
+package app
+
+import "context"
+
+// TestCaseStore - muammoning test case'larini olib keladigan manba interfeysi.
+// Turli backend'lar (Postgres, SQLite, lokal fayllar, xotiradagi) shu interfeysni
+// amalga oshirishi mumkin, shunda ExecuteCode qaysi manbadan foydalanilayotganini
+// bilmaydi va modul Neon'siz ham ishlatilishi mumkin.
+type TestCaseStore interface {
+	FetchByProblemID(ctx context.Context, problemID int) ([]TestCase, error)
+}
+
+// TestCaseWriter - test case'larni yozish imkoniyatiga ega TestCaseStore kengaytmasi.
+// MigrateFromDirectory shu interfeys orqali yozadi, fayl/SQLite/xotira store'lari
+// uni amalga oshiradi;
+// Postgres store'i odatda faqat o'qish uchun ishlatilgani sababli buni amalga
+// oshirishi shart emas.
+type TestCaseWriter interface {
+	SaveTestCases(ctx context.Context, problemID int, cases []TestCase) error
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testCaseStoreFactories - bu fayldagi testlar har bir implementatsiya ustida bir xil
+// xatti-harakatni tekshirish uchun ishlatiladigan TestCaseWriter+TestCaseStore
+// fabrikalari. Postgres bu yerga kirmaydi, chunki u tashqi bazaga muhtoj.
+func testCaseStoreFactories(t *testing.T) map[string]func() interface {
+	TestCaseStore
+	TestCaseWriter
+} {
+	return map[string]func() interface {
+		TestCaseStore
+		TestCaseWriter
+	}{
+		"memory": func() interface {
+			TestCaseStore
+			TestCaseWriter
+		} {
+			return NewMemoryTestCaseStore()
+		},
+		"file": func() interface {
+			TestCaseStore
+			TestCaseWriter
+		} {
+			return NewFileTestCaseStore(t.TempDir())
+		},
+		"sqlite": func() interface {
+			TestCaseStore
+			TestCaseWriter
+		} {
+			store, err := NewSQLiteTestCaseStore(filepath.Join(t.TempDir(), "testcases.db"))
+			if err != nil {
+				t.Fatalf("NewSQLiteTestCaseStore: %v", err)
+			}
+			return store
+		},
+	}
+}
+
+func TestTestCaseStoresFetchNotFound(t *testing.T) {
+	for name, newStore := range testCaseStoreFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			if _, err := store.FetchByProblemID(context.Background(), 404); err == nil {
+				t.Errorf("FetchByProblemID on an unknown problem should error")
+			}
+		})
+	}
+}
+
+func TestTestCaseStoresSaveAndFetch(t *testing.T) {
+	for name, newStore := range testCaseStoreFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			ctx := context.Background()
+
+			cases := []TestCase{
+				{ID: 1, InputText: "2 3\n", OutputText: "5\n"},
+				{ID: 2, InputText: "10 20\n", OutputText: "30\n"},
+			}
+			if err := store.SaveTestCases(ctx, 42, cases); err != nil {
+				t.Fatalf("SaveTestCases: %v", err)
+			}
+
+			got, err := store.FetchByProblemID(ctx, 42)
+			if err != nil {
+				t.Fatalf("FetchByProblemID: %v", err)
+			}
+			if len(got) != len(cases) {
+				t.Fatalf("got %d testcases, want %d", len(got), len(cases))
+			}
+			for i := range cases {
+				if got[i].InputText != cases[i].InputText || got[i].OutputText != cases[i].OutputText {
+					t.Errorf("testcase %d = %+v, want input/output %+v", i, got[i], cases[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTestCaseStoresSaveOverwritesPreviousTestCases(t *testing.T) {
+	for name, newStore := range testCaseStoreFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			ctx := context.Background()
+
+			if err := store.SaveTestCases(ctx, 7, []TestCase{{ID: 1, InputText: "old", OutputText: "old"}}); err != nil {
+				t.Fatalf("SaveTestCases (first): %v", err)
+			}
+			if err := store.SaveTestCases(ctx, 7, []TestCase{{ID: 1, InputText: "new", OutputText: "new"}}); err != nil {
+				t.Fatalf("SaveTestCases (second): %v", err)
+			}
+
+			got, err := store.FetchByProblemID(ctx, 7)
+			if err != nil {
+				t.Fatalf("FetchByProblemID: %v", err)
+			}
+			if len(got) != 1 || got[0].InputText != "new" {
+				t.Errorf("SaveTestCases should replace, not append: got %+v", got)
+			}
+		})
+	}
+}
+
+func TestMigrateFromDirectory(t *testing.T) {
+	root := t.TempDir()
+	problemDir := filepath.Join(root, "42")
+	writeFixture(t, filepath.Join(problemDir, "input_1.txt"), "2 3\n")
+	writeFixture(t, filepath.Join(problemDir, "output_1.txt"), "5\n")
+	writeFixture(t, filepath.Join(problemDir, "input_2.txt"), "10 20\n")
+	writeFixture(t, filepath.Join(problemDir, "output_2.txt"), "30\n")
+	// Raqam bo'lmagan katalog nomi e'tiborga olinmasligi kerak
+	writeFixture(t, filepath.Join(root, "not-a-problem", "input_1.txt"), "ignored")
+
+	dest := NewMemoryTestCaseStore()
+	if err := MigrateFromDirectory(context.Background(), root, dest); err != nil {
+		t.Fatalf("MigrateFromDirectory: %v", err)
+	}
+
+	got, err := dest.FetchByProblemID(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("FetchByProblemID: %v", err)
+	}
+	want := []TestCase{
+		{ID: 1, InputText: "2 3\n", OutputText: "5\n"},
+		{ID: 2, InputText: "10 20\n", OutputText: "30\n"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("migrated testcases = %+v, want %+v", got, want)
+	}
+
+	if _, err := dest.FetchByProblemID(context.Background(), 0); err == nil {
+		t.Errorf("non-numeric directory name should not have been migrated")
+	}
+}
+
+// TestMigrateFromDirectoryOrdersNumerically 10 tadan ortiq test case'li muammoda
+// input_10.txt, input_2.txt kabi fayllar lug'aviy emas, sonli tartibda
+// o'qilishini tekshiradi (input_1, input_2, ..., input_11).
+func TestMigrateFromDirectoryOrdersNumerically(t *testing.T) {
+	root := t.TempDir()
+	problemDir := filepath.Join(root, "7")
+	for n := 1; n <= 11; n++ {
+		writeFixture(t, filepath.Join(problemDir, fmt.Sprintf("input_%d.txt", n)), fmt.Sprintf("in-%d\n", n))
+		writeFixture(t, filepath.Join(problemDir, fmt.Sprintf("output_%d.txt", n)), fmt.Sprintf("out-%d\n", n))
+	}
+
+	dest := NewMemoryTestCaseStore()
+	if err := MigrateFromDirectory(context.Background(), root, dest); err != nil {
+		t.Fatalf("MigrateFromDirectory: %v", err)
+	}
+
+	got, err := dest.FetchByProblemID(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("FetchByProblemID: %v", err)
+	}
+	if len(got) != 11 {
+		t.Fatalf("got %d testcases, want 11", len(got))
+	}
+	for i, tc := range got {
+		wantN := i + 1
+		if tc.ID != wantN || tc.InputText != fmt.Sprintf("in-%d\n", wantN) || tc.OutputText != fmt.Sprintf("out-%d\n", wantN) {
+			t.Errorf("testcase at position %d = %+v, want ID/input/output for N=%d", i, tc, wantN)
+		}
+	}
+}
+
+func writeFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("writeFixture(%s): %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writeFixture(%s): %v", path, err)
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"os"
+	"sync"
 
 	"github.com/Coding-for-Machine/leetcode-docker/app" // app paketini import qilish
 	"github.com/fasthttp/websocket"
@@ -11,6 +13,39 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// testCaseStore - butun server hayoti davomida qayta ishlatiladigan, Problem ID
+// asosidagi test case'larni taqdim etuvchi umumiy store. main() ichida
+// konfiguratsiya qilinadi va har bir WebSocket ulanishiga ExecuteCode orqali
+// bog'lab beriladi.
+var testCaseStore app.TestCaseStore
+
+// newTestCaseStore - muhit o'zgaruvchilariga qarab tegishli TestCaseStore
+// implementatsiyasini tanlaydi: DATABASE_URL berilgan bo'lsa Postgres, SQLITE_PATH
+// berilgan bo'lsa SQLite, TESTCASE_DIR berilgan bo'lsa fayl asosidagi store, aks
+// holda xotiradagi (bo'sh) store - bunda faqat custom input va manual test
+// case'lar ishlaydi.
+func newTestCaseStore(ctx context.Context) app.TestCaseStore {
+	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
+		store, err := app.NewPostgresTestCaseStore(ctx, dsn)
+		if err != nil {
+			log.Fatal("TestCaseStore (Postgres) yaratishda xato:", err)
+		}
+		return store
+	}
+	if path := os.Getenv("SQLITE_PATH"); path != "" {
+		store, err := app.NewSQLiteTestCaseStore(path)
+		if err != nil {
+			log.Fatal("TestCaseStore (SQLite) yaratishda xato:", err)
+		}
+		return store
+	}
+	if dir := os.Getenv("TESTCASE_DIR"); dir != "" {
+		return app.NewFileTestCaseStore(dir)
+	}
+	log.Println("Warning: DATABASE_URL, SQLITE_PATH yoki TESTCASE_DIR sozlanmagan, Problem ID asosidagi so'rovlar ishlamaydi")
+	return app.NewMemoryTestCaseStore()
+}
+
 const (
 	ALLOWED_ORIGINS = "*"
 	ALLOWED_METHODS = "GET, POST, OPTIONS" // OPTIONS faqat CORS preflight uchun
@@ -38,6 +73,11 @@ type RequestPayload struct {
 	CpuShares   int            `json:"cpu_shares"`
 }
 
+// ControlMessage - ijroni boshqarish uchun keladigan xabar (masalan, {"type":"cancel"})
+type ControlMessage struct {
+	Type string `json:"type"`
+}
+
 func handler(ctx *fasthttp.RequestCtx) {
 	// CORS sozlamalari
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", ALLOWED_ORIGINS)
@@ -55,6 +95,29 @@ func handler(ctx *fasthttp.RequestCtx) {
 		if string(ctx.Method()) == "GET" {
 			err := upgrader.Upgrade(ctx, func(conn *websocket.Conn) {
 				defer conn.Close()
+
+				// writeMu - bir nechta goroutine (oqim yuboruvchi va asosiy tsikl)
+				// bitta WebSocket ulanishiga bir vaqtda yozishining oldini oladi
+				var writeMu sync.Mutex
+				writeJSON := func(v interface{}) error {
+					data, err := json.Marshal(v)
+					if err != nil {
+						return err
+					}
+					writeMu.Lock()
+					defer writeMu.Unlock()
+					return conn.WriteMessage(websocket.TextMessage, data)
+				}
+
+				// cancelMu - joriy ijroni bekor qiluvchi funksiyani "cancel" boshqaruv
+				// xabari kelguncha saqlab turadi. cancelGen har yangi so'rovda oshiriladi,
+				// shunda bir so'rov tugaganda faqat o'ziga tegishli bo'lsagina cancelCurrent'ni
+				// tozalaydi va keyingi (hali ijro etilayotgan) so'rovni bekor qilish imkoniyatini
+				// yo'qotib qo'ymaydi.
+				var cancelMu sync.Mutex
+				var cancelCurrent context.CancelFunc
+				var cancelGen uint64
+
 				for {
 					_, msg, err := conn.ReadMessage()
 					if err != nil {
@@ -63,10 +126,20 @@ func handler(ctx *fasthttp.RequestCtx) {
 					}
 					log.Printf("Received via WebSocket: %s", msg)
 
+					var control ControlMessage
+					if err := json.Unmarshal(msg, &control); err == nil && control.Type == "cancel" {
+						cancelMu.Lock()
+						if cancelCurrent != nil {
+							cancelCurrent()
+						}
+						cancelMu.Unlock()
+						continue
+					}
+
 					var req RequestPayload
 					if err := json.Unmarshal(msg, &req); err != nil {
 						log.Println("WebSocket JSON parse error:", err)
-						conn.WriteMessage(websocket.TextMessage, []byte(`{"overall_status": "Error", "error": "Invalid JSON format"}`))
+						writeJSON(map[string]string{"overall_status": "Error", "error": "Invalid JSON format"})
 						continue
 					}
 
@@ -81,28 +154,48 @@ func handler(ctx *fasthttp.RequestCtx) {
 						req.CpuShares = 512
 					}
 
-					// app.ExecuteCode funksiyasini chaqirish (u endi barcha test turlarini boshqaradi)
-					result := app.ExecuteCode(app.ExecutionRequest{
-						ProblemID:   req.ProblemID,
-						CustomInput: req.CustomInput,
-						TestCases:   req.TestCases,
-						Code:        req.Code,
-						Language:    req.Language,
-						TimeoutMs:   req.TimeoutMs,
-						MemoryMb:    req.MemoryMb,
-						CpuShares:   req.CpuShares,
-					})
-
-					// Natijani JSON formatida WebSocket orqali qaytarish
-					responseBytes, err := json.Marshal(result)
-					if err != nil {
-						log.Println("WebSocket result marshal error:", err)
-						responseBytes = []byte(`{"overall_status": "Error", "error": "Failed to marshal result"}`)
-					}
-					if err = conn.WriteMessage(websocket.TextMessage, responseBytes); err != nil {
-						log.Println("WebSocket write error:", err)
-						break
+					execCtx, cancel := context.WithCancel(context.Background())
+					cancelMu.Lock()
+					if cancelCurrent != nil {
+						cancelCurrent() // Oldingi ijro hali tugamagan bo'lsa, yangisi boshlanishidan oldin bekor qilinadi
 					}
+					cancelCurrent = cancel
+					cancelGen++
+					myGen := cancelGen
+					cancelMu.Unlock()
+
+					// app.ExecuteCode funksiyasini alohida goroutine'da chaqirish, shunda asosiy
+					// tsikl ijro davomida kelgan "cancel" xabarlarini o'qishda davom etadi.
+					// sink orqali har bir test case tugashi bilan natija, progress va yakuniy
+					// summary alohida WebSocket freymi sifatida oqim bo'lib yuboriladi.
+					go func(req RequestPayload, execCtx context.Context, cancel context.CancelFunc, gen uint64) {
+						defer cancel()
+						sink := func(event app.ExecutionEvent) {
+							if err := writeJSON(event); err != nil {
+								log.Println("WebSocket stream write error:", err)
+							}
+						}
+
+						app.ExecuteCode(execCtx, testCaseStore, app.ExecutionRequest{
+							ProblemID:   req.ProblemID,
+							CustomInput: req.CustomInput,
+							TestCases:   req.TestCases,
+							Code:        req.Code,
+							Language:    req.Language,
+							TimeoutMs:   req.TimeoutMs,
+							MemoryMb:    req.MemoryMb,
+							CpuShares:   req.CpuShares,
+						}, sink)
+
+						// Faqat hali ham shu so'rovga tegishli bo'lsa tozalaymiz - aks holda
+						// keyingi so'rov allaqachon o'z cancel funksiyasini o'rnatgan bo'ladi
+						// va uni bu yerda nolga tushirib qo'yish "cancel" xabarini yo'qotadi.
+						cancelMu.Lock()
+						if cancelGen == gen {
+							cancelCurrent = nil
+						}
+						cancelMu.Unlock()
+					}(req, execCtx, cancel, myGen)
 				}
 			})
 			if err != nil {
@@ -130,6 +223,8 @@ func main() {
 		port = "8080" // Default portni 8080 ga o'zgartirdik, chunki docker-compose da shunday
 	}
 
+	testCaseStore = newTestCaseStore(context.Background())
+
 	log.Printf("Server running on :%s", port)
 	err := fasthttp.ListenAndServe(":"+port, handler)
 